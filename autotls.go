@@ -0,0 +1,43 @@
+package httpserver
+
+import (
+	"crypto/tls"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// DefaultAutoTLSChallengeAddr is the address of the ACME HTTP-01 challenge listener started by
+// AutoTLS when AutoTLSChallengeAddr is not set.
+const DefaultAutoTLSChallengeAddr = ":80"
+
+// AutoTLS switches the server into TLS mode backed by Let's Encrypt (or another ACME provider),
+// obtaining and renewing certificates automatically for the given hosts and caching them under
+// cacheDir on disk. It installs an autocert.Manager as the server's GetCertificate callback and
+// starts a companion HTTP-01 challenge listener alongside the main listener in ListenAndServe.
+func AutoTLS(hosts []string, cacheDir string) OptionFunc {
+	return func(server *Server) {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(hosts...),
+			Cache:      autocert.DirCache(cacheDir),
+		}
+
+		if server.TLSConfig == nil {
+			server.TLSConfig = &tls.Config{}
+		}
+		server.TLSConfig.GetCertificate = manager.GetCertificate
+
+		server.autocertManager = manager
+		if server.autocertChallengeAddr == "" {
+			server.autocertChallengeAddr = DefaultAutoTLSChallengeAddr
+		}
+	}
+}
+
+// AutoTLSChallengeAddr overrides the address of the HTTP-01 challenge listener started by
+// AutoTLS. It has no effect unless AutoTLS is also set.
+func AutoTLSChallengeAddr(addr string) OptionFunc {
+	return func(server *Server) {
+		server.autocertChallengeAddr = addr
+	}
+}
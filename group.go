@@ -0,0 +1,51 @@
+package httpserver
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Group runs multiple Servers under a single context, so that any one server's failure cancels
+// the rest. It is meant for binaries that expose more than one endpoint, e.g. a main API server
+// alongside an admin/pprof/metrics server on a separate port.
+type Group struct {
+	servers []*Server
+}
+
+// NewGroup creates an empty Group. Use Add to register servers before calling Run.
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Add registers server to be started by Run.
+func (group *Group) Add(server *Server) {
+	group.servers = append(group.servers, server)
+}
+
+// Run starts every registered server and blocks until all of them have completed their
+// graceful shutdown. If any server returns an error, its context is canceled so the rest shut
+// down too, and Run returns that error.
+func (group *Group) Run(ctx context.Context) error {
+	errGroup, groupContext := errgroup.WithContext(ctx)
+
+	for _, server := range group.servers {
+		server := server
+		errGroup.Go(func() error {
+			return server.ListenAndServe(groupContext)
+		})
+	}
+
+	return errGroup.Wait()
+}
+
+// RunWithSignals behaves like Run, but also installs a signal.NotifyContext handler so callers
+// do not have to reinvent SIGINT/SIGTERM handling for every binary.
+func (group *Group) RunWithSignals(ctx context.Context, signals ...os.Signal) error {
+	signalContext, stop := signal.NotifyContext(ctx, signals...)
+	defer stop()
+
+	return group.Run(signalContext)
+}
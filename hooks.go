@@ -0,0 +1,36 @@
+package httpserver
+
+// BeforeShutdown registers a hook that is called when shutdown is requested, before anything
+// else happens. If it returns false, the shutdown is vetoed and the server keeps serving; the
+// hook is called again after shutdownVetoRetryInterval until it returns true. Use it to let
+// in-flight batch operations finish before the server goes down.
+func BeforeShutdown(fn func() bool) OptionFunc {
+	return func(server *Server) {
+		server.beforeShutdown = fn
+	}
+}
+
+// ShutdownInitiated registers a hook that is called synchronously once shutdown has been
+// approved (see BeforeShutdown), before the server stops accepting new connections.
+func ShutdownInitiated(fn func()) OptionFunc {
+	return func(server *Server) {
+		server.shutdownInitiated = fn
+	}
+}
+
+// AfterShutdown registers a hook that is called once Shutdown has returned, with its error (nil
+// on a clean shutdown).
+func AfterShutdown(fn func(error)) OptionFunc {
+	return func(server *Server) {
+		server.afterShutdown = fn
+	}
+}
+
+// OnShutdown registers fn to run when the server begins to shut down, via the embedded
+// http.Server's RegisterOnShutdown. Unlike ShutdownInitiated it is run in its own goroutine and
+// is meant for piggy-backing per-connection cleanup on the standard library's own hook.
+func OnShutdown(fn func()) OptionFunc {
+	return func(server *Server) {
+		server.RegisterOnShutdown(fn)
+	}
+}
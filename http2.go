@@ -0,0 +1,32 @@
+package httpserver
+
+import (
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// HTTP2 configures the embedded http.Server for HTTP/2 using cfg (or the library defaults if
+// cfg is nil), so that TLS connections negotiating h2 via ALPN are served over HTTP/2. It has
+// no effect on plaintext listeners; use H2C for those. Set it after any user-provided
+// TLSNextProto option, since http2.ConfigureServer merges into whatever is already there.
+func HTTP2(cfg *http2.Server) OptionFunc {
+	return func(server *Server) {
+		if cfg == nil {
+			cfg = &http2.Server{}
+		}
+		if err := http2.ConfigureServer(server.Server, cfg); err != nil {
+			server.logf("failed to configure HTTP/2: %v", err)
+		}
+	}
+}
+
+// H2C enables cleartext HTTP/2 (h2c) by wrapping the server's current Handler with
+// h2c.NewHandler, so plaintext listeners can serve HTTP/2 upgrade requests without TLS --
+// useful for gRPC-gateway and service-mesh sidecar deployments. Set it after any option that
+// replaces Handler; it has no bearing on HTTP2/TLSConfig/TLSNextProto, which configure the TLS
+// path independently.
+func H2C() OptionFunc {
+	return func(server *Server) {
+		server.Handler = h2c.NewHandler(server.Handler, &http2.Server{})
+	}
+}
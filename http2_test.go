@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/http2"
+)
+
+func TestHTTP2_ClientNegotiatesH2OverTLS(t *testing.T) {
+	server := New("127.0.0.1:0", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Proto)
+	}), HTTP2(nil))
+
+	ts := httptest.NewUnstartedServer(nil)
+	ts.Config = server.Server
+	ts.TLS = server.TLSConfig
+	ts.StartTLS()
+	defer ts.Close()
+
+	client := ts.Client()
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("ts.Client() transport is %T, want *http.Transport", client.Transport)
+	}
+	if err := http2.ConfigureTransport(transport); err != nil {
+		t.Fatalf("configure http2 transport: %v", err)
+	}
+
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("GET %s: %v", ts.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if got := string(body); got != "HTTP/2.0" {
+		t.Errorf("r.Proto = %q, want HTTP/2.0 (client did not negotiate h2)", got)
+	}
+}
+
+func TestH2C_ClientNegotiatesH2COverCleartext(t *testing.T) {
+	server := New("127.0.0.1:0", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Proto)
+	}), H2C())
+
+	ts := httptest.NewServer(server.Server.Handler)
+	defer ts.Close()
+
+	client := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("GET %s: %v", ts.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if got := string(body); got != "HTTP/2.0" {
+		t.Errorf("r.Proto = %q, want HTTP/2.0 (client did not negotiate h2c)", got)
+	}
+}
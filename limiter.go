@@ -0,0 +1,95 @@
+package httpserver
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// MaxConnections bounds the number of connections open at once, across every listener, to n.
+// Once the limit is reached, Accept blocks until one of the existing connections closes.
+func MaxConnections(n int) OptionFunc {
+	return func(server *Server) {
+		server.maxConnections = n
+	}
+}
+
+// TCPKeepAlive enables TCP keep-alive probes with the given period on every accepted
+// *net.TCPConn, pruning dead clients that would otherwise hold a connection open indefinitely.
+func TCPKeepAlive(period time.Duration) OptionFunc {
+	return func(server *Server) {
+		server.tcpKeepAlive = period
+	}
+}
+
+// wrapListener applies MaxConnections and TCPKeepAlive, if configured, to ln.
+func (server *Server) wrapListener(ln net.Listener) net.Listener {
+	if server.tcpKeepAlive > 0 {
+		ln = &tcpKeepAliveListener{Listener: ln, period: server.tcpKeepAlive}
+	}
+	if server.maxConnections > 0 {
+		server.connSemOnce.Do(func() {
+			server.connSem = make(chan struct{}, server.maxConnections)
+		})
+		ln = newConnLimitListener(ln, server.connSem)
+	}
+
+	return ln
+}
+
+type tcpKeepAliveListener struct {
+	net.Listener
+	period time.Duration
+}
+
+func (l *tcpKeepAliveListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetKeepAlive(true)
+		tcpConn.SetKeepAlivePeriod(l.period)
+	}
+
+	return conn, nil
+}
+
+// connLimitListener wraps a net.Listener with a semaphore shared across every listener of the
+// owning Server, so that at most n Accept'ed connections are open at once across all of them;
+// Accept blocks once the limit is reached.
+type connLimitListener struct {
+	net.Listener
+	sem chan struct{}
+}
+
+func newConnLimitListener(ln net.Listener, sem chan struct{}) *connLimitListener {
+	return &connLimitListener{Listener: ln, sem: sem}
+}
+
+func (l *connLimitListener) Accept() (net.Conn, error) {
+	l.sem <- struct{}{}
+
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sem
+		return nil, err
+	}
+
+	return &limitConn{Conn: conn, release: func() { <-l.sem }}, nil
+}
+
+// limitConn releases its slot in the owning connLimitListener's semaphore exactly once, on
+// Close.
+type limitConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
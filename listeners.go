@@ -0,0 +1,133 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/coreos/go-systemd/activation"
+)
+
+// listener describes a single endpoint the server accepts connections on.
+type listener struct {
+	name     string
+	tls      bool
+	certFile string
+	keyFile  string
+	open     func() (net.Listener, error)
+}
+
+// EnableHTTP adds a plain HTTP listener bound to addr. Registering any Enable* listener replaces
+// the default listener for the addr passed to New, so include addr again in an EnableHTTP (or
+// other Enable*) call if the server should still listen on it.
+func EnableHTTP(addr string) OptionFunc {
+	return func(server *Server) {
+		server.listeners = append(server.listeners, &listener{
+			name: fmt.Sprintf("http://%s", addr),
+			open: func() (net.Listener, error) { return net.Listen("tcp", addr) },
+		})
+	}
+}
+
+// EnableHTTPS adds a TLS listener bound to addr, serving the certificate and key loaded from
+// certFile and keyFile.
+func EnableHTTPS(addr, certFile, keyFile string) OptionFunc {
+	return func(server *Server) {
+		server.listeners = append(server.listeners, &listener{
+			name:     fmt.Sprintf("https://%s", addr),
+			tls:      true,
+			certFile: certFile,
+			keyFile:  keyFile,
+			open:     func() (net.Listener, error) { return net.Listen("tcp", addr) },
+		})
+	}
+}
+
+// EnableUnixSocket adds a listener bound to the Unix domain socket at path, chmod'd to mode
+// once created.
+func EnableUnixSocket(path string, mode os.FileMode) OptionFunc {
+	return func(server *Server) {
+		server.listeners = append(server.listeners, &listener{
+			name: fmt.Sprintf("unix://%s", path),
+			open: func() (net.Listener, error) {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					return nil, err
+				}
+
+				ln, err := net.Listen("unix", path)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := os.Chmod(path, mode); err != nil {
+					ln.Close()
+					return nil, err
+				}
+
+				return ln, nil
+			},
+		})
+	}
+}
+
+// EnableSystemdSocket adds a listener bound to the socket passed in by systemd socket
+// activation under the given FileDescriptorName (see systemd.socket(5)).
+func EnableSystemdSocket(name string) OptionFunc {
+	return func(server *Server) {
+		server.listeners = append(server.listeners, &listener{
+			name: fmt.Sprintf("systemd://%s", name),
+			open: func() (net.Listener, error) {
+				listeners, err := activation.ListenersWithNames()
+				if err != nil {
+					return nil, err
+				}
+
+				named, ok := listeners[name]
+				if !ok || len(named) == 0 {
+					return nil, fmt.Errorf("no systemd socket activation listener named %q", name)
+				}
+
+				return named[0], nil
+			},
+		})
+	}
+}
+
+// defaultListener builds the listener used when no Enable* option has registered one, i.e. the
+// address passed to New, upgraded to TLS when AutoTLS is set.
+func (server *Server) defaultListener() *listener {
+	scheme := "http"
+	if server.autocertManager != nil {
+		scheme = "https"
+	}
+
+	return &listener{
+		name: fmt.Sprintf("%s://%s", scheme, server.Addr),
+		tls:  server.autocertManager != nil,
+		open: func() (net.Listener, error) { return net.Listen("tcp", server.Addr) },
+	}
+}
+
+func (server *Server) serveListener(l *listener) error {
+	ln, err := l.open()
+	if err != nil {
+		return fmt.Errorf("could not open listener %s: %w", l.name, err)
+	}
+	ln = server.wrapListener(ln)
+
+	var serveErr error
+	switch {
+	case l.tls:
+		serveErr = server.Server.ServeTLS(ln, l.certFile, l.keyFile)
+	default:
+		serveErr = server.Server.Serve(ln)
+	}
+
+	if serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+		return fmt.Errorf("could not serve %s: %w", l.name, serveErr)
+	}
+
+	return nil
+}
@@ -8,7 +8,10 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"sync"
 	"time"
+
+	"golang.org/x/crypto/acme/autocert"
 )
 
 const DefaultShutdownTimeout = time.Second
@@ -18,6 +21,21 @@ type Server struct {
 	*http.Server
 
 	shutdownTimeout time.Duration
+
+	autocertManager       *autocert.Manager
+	autocertChallengeAddr string
+	challengeServer       *http.Server
+
+	listeners []*listener
+
+	beforeShutdown    func() bool
+	shutdownInitiated func()
+	afterShutdown     func(error)
+
+	maxConnections int
+	connSem        chan struct{}
+	connSemOnce    sync.Once
+	tcpKeepAlive   time.Duration
 }
 
 type OptionFunc func(server *Server)
@@ -112,32 +130,113 @@ func New(addr string, handler http.Handler, options ...OptionFunc) *Server {
 	return server
 }
 
+// ListenAndServe opens every configured listener (those registered via EnableHTTP, EnableHTTPS,
+// EnableUnixSocket or EnableSystemdSocket, or the address passed to New if none is) and
+// serves them until ctx is done, at which point it gracefully shuts the server down within
+// shutdownTimeout. It blocks until all listeners have stopped and returns their joined errors.
 func (server *Server) ListenAndServe(ctx context.Context) error {
+	listeners := server.listeners
+	if len(listeners) == 0 {
+		listeners = []*listener{server.defaultListener()}
+	}
+
+	// The challenge server, if any, is built and assigned to server.challengeServer before
+	// watchShutdown is launched, so the watcher never observes a nil challengeServer and
+	// always shuts it down, however early shutdownCtx ends up being canceled.
+	if server.autocertManager != nil {
+		server.challengeServer = &http.Server{
+			Addr:    server.autocertChallengeAddr,
+			Handler: server.autocertManager.HTTPHandler(nil),
+		}
+	}
+
+	// shutdownCtx is canceled either when ctx is done or when any listener fails, so a
+	// bind/serve error tears the rest of the group down instead of leaving ListenAndServe
+	// blocked on <-done forever.
+	shutdownCtx, cancelShutdown := context.WithCancel(ctx)
+	defer cancelShutdown()
+
 	done := make(chan struct{}, 1)
+	go server.watchShutdown(shutdownCtx, done)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(listeners)+1)
+
+	for _, l := range listeners {
+		wg.Add(1)
+		go func(l *listener) {
+			defer wg.Done()
+			err := server.serveListener(l)
+			if err != nil {
+				cancelShutdown()
+			}
+			errs <- err
+		}(l)
+	}
+
+	if server.challengeServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := server.challengeServer.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				cancelShutdown()
+				errs <- fmt.Errorf("could not listen on %s: %w", server.challengeServer.Addr, err)
+				return
+			}
+			errs <- nil
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
 
-	go func() {
-		<-ctx.Done()
+	<-done
 
-		shutdownContext, cancel := context.WithTimeout(context.Background(), server.shutdownTimeout)
-		defer cancel()
+	var joined error
+	for err := range errs {
+		joined = errors.Join(joined, err)
+	}
 
-		server.SetKeepAlivesEnabled(false)
+	return joined
+}
 
-		if err := server.Shutdown(shutdownContext); err != nil {
-			server.logf("failed to gracefully shutdown the server: %v", err)
-		}
+// shutdownVetoRetryInterval is how long watchShutdown waits before re-checking BeforeShutdown
+// after it has vetoed a shutdown.
+const shutdownVetoRetryInterval = time.Second
 
-		close(done)
-	}()
+func (server *Server) watchShutdown(ctx context.Context, done chan<- struct{}) {
+	<-ctx.Done()
 
-	err := server.Server.ListenAndServe()
-	if err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return fmt.Errorf("could not listen on %s: %w", server.Addr, err)
+	for server.beforeShutdown != nil && !server.beforeShutdown() {
+		server.logf("shutdown vetoed by BeforeShutdown hook, retrying in %s", shutdownVetoRetryInterval)
+		time.Sleep(shutdownVetoRetryInterval)
 	}
 
-	<-done
+	if server.shutdownInitiated != nil {
+		server.shutdownInitiated()
+	}
+
+	shutdownContext, cancel := context.WithTimeout(context.Background(), server.shutdownTimeout)
+	defer cancel()
+
+	server.SetKeepAlivesEnabled(false)
+
+	err := server.Shutdown(shutdownContext)
+	if err != nil {
+		server.logf("failed to gracefully shutdown the server: %v", err)
+	}
+	if server.challengeServer != nil {
+		if chErr := server.challengeServer.Shutdown(shutdownContext); chErr != nil {
+			server.logf("failed to gracefully shutdown the ACME challenge listener: %v", chErr)
+		}
+	}
+
+	if server.afterShutdown != nil {
+		server.afterShutdown(err)
+	}
 
-	return nil
+	close(done)
 }
 
 func (server *Server) logf(format string, v ...interface{}) {